@@ -17,23 +17,31 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/Adron/adrons-core-platform-cli/internal/config"
+	"github.com/Adron/adrons-core-platform-cli/internal/logging"
+	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 // configCmd represents the config command
 var configCmd = &cobra.Command{
-	Use:   "config",
-	Short: "The 'config' subcommand is for use in management of configuration.",
+	Use:               "config",
+	PersistentPreRunE: initLogging,
+	Short:             "The 'config' subcommand is for use in management of configuration.",
 	Long: func() string {
 		baseDesc := `The 'config' subcommand is for use in management of configuration. It can be used, in combination with the
 other subcommands 'add', 'update', 'view', and 'delete'.
 
-Configuration should be provided via config.json file. See config.sample.json for an example.`
+Configuration is read from a config file (JSON, YAML or TOML, detected by
+extension) in the current directory, $HOME/.adrons-core or /etc/adrons-core,
+and can be overridden with ACP_-prefixed environment variables or flags.
+See config.sample.json for an example.`
 
 		// Only append debug info if DEBUG is true
-		if viper.GetBool("DEBUG") {
+		if config.Global.Debug() {
 			debugInfo := fmt.Sprintf(`
 
 Debug Information:
@@ -41,9 +49,9 @@ Debug Information:
 Postgres URL: %s
 Username: %s
 Debug Mode: %v`,
-				viper.GetString("POSTGRES_URL"),
-				viper.GetString("USERNAME"),
-				viper.GetBool("DEBUG"))
+				config.Global.PostgresURL(),
+				config.Global.Username(),
+				config.Global.Debug())
 
 			return baseDesc + debugInfo
 		}
@@ -51,35 +59,206 @@ Debug Mode: %v`,
 		return baseDesc
 	}(),
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Current Configuration:")
-		fmt.Println("---------------------")
+		printConfig()
+	},
+}
+
+// viewCmd represents the config view subcommand
+var viewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Print the current configuration",
+	Run: func(cmd *cobra.Command, args []string) {
+		printConfig()
+	},
+}
+
+// addCmd represents the config add subcommand
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a new key/value pair to the configuration file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, value, err := keyValueFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		path := configFilePath()
+		settings, err := config.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if _, exists := settings[key]; exists {
+			return fmt.Errorf("key %q already exists in %s, use 'config update' instead", key, path)
+		}
+
+		settings[key] = value
+		if err := config.WriteFileAtomic(path, settings); err != nil {
+			return err
+		}
+
+		viper.Set(key, value)
+		config.Global.LoadFromViper(viper.GetViper())
+
+		fmt.Printf("Added %s = %s to %s\n", key, value, path)
+		return nil
+	},
+}
+
+// updateCmd represents the config update subcommand
+var updateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Update an existing key's value in the configuration file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, value, err := keyValueFlags(cmd)
+		if err != nil {
+			return err
+		}
+
+		path := configFilePath()
+		settings, err := config.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		if _, exists := settings[key]; !exists {
+			return fmt.Errorf("key %q not found in %s, use 'config add' instead", key, path)
+		}
+
+		settings[key] = value
+		if err := config.WriteFileAtomic(path, settings); err != nil {
+			return err
+		}
+
+		viper.Set(key, value)
+		config.Global.LoadFromViper(viper.GetViper())
+
+		fmt.Printf("Updated %s = %s in %s\n", key, value, path)
+		return nil
+	},
+}
+
+// deleteCmd represents the config delete subcommand
+var deleteCmd = &cobra.Command{
+	Use:   "delete",
+	Short: "Delete a key from the configuration file",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		key, err := cmd.Flags().GetString("key")
+		if err != nil {
+			return err
+		}
+		if key == "" {
+			return fmt.Errorf("--key is required")
+		}
+
+		path := configFilePath()
+		settings, err := config.ReadFile(path)
+		if err != nil {
+			return err
+		}
 
-		// Get all settings from viper
-		allSettings := viper.AllSettings()
+		if _, exists := settings[key]; !exists {
+			return fmt.Errorf("key %q not found in %s", key, path)
+		}
 
-		// Print each key-value pair
-		for key, value := range allSettings {
-			fmt.Printf("%s: %v\n", key, value)
+		delete(settings, key)
+		if err := config.WriteFileAtomic(path, settings); err != nil {
+			return err
 		}
+
+		fmt.Printf("Deleted %s from %s\n", key, path)
+		return nil
 	},
 }
 
+// printConfig prints every setting viper currently knows about, merged
+// across config file, environment and flags.
+func printConfig() {
+	fmt.Println("Current Configuration:")
+	fmt.Println("---------------------")
+
+	for key, value := range viper.AllSettings() {
+		fmt.Printf("%s: %v\n", key, value)
+	}
+}
+
+// keyValueFlags reads the --key/--value persistent flags shared by add
+// and update, returning an error if --key is missing. An empty --value is
+// accepted.
+func keyValueFlags(cmd *cobra.Command) (string, string, error) {
+	key, err := cmd.Flags().GetString("key")
+	if err != nil {
+		return "", "", err
+	}
+	value, err := cmd.Flags().GetString("value")
+	if err != nil {
+		return "", "", err
+	}
+	if key == "" {
+		return "", "", fmt.Errorf("--key is required")
+	}
+	return key, value, nil
+}
+
+// configFilePath returns the config file viper loaded, or a sane default
+// in the current directory if none was found yet.
+func configFilePath() string {
+	if path := viper.ConfigFileUsed(); path != "" {
+		return path
+	}
+	return "config.json"
+}
+
 func init() {
 	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(viewCmd)
+	configCmd.AddCommand(addCmd)
+	configCmd.AddCommand(updateCmd)
+	configCmd.AddCommand(deleteCmd)
+
 	configCmd.PersistentFlags().StringP("key", "k", "", "The key for the key value set to add to the configuration.")
 	configCmd.PersistentFlags().StringP("value", "v", "", "The value for the key value set to add to the configuration.")
 
-	// Setup Viper for JSON config
-	viper.SetConfigName("config") // name of config file (without extension)
-	viper.SetConfigType("json")   // REQUIRED if the config file does not have the extension in the name
-	viper.AddConfigPath(".")      // look for config in the working directory
+	// Env vars override config file values (bound flags still take
+	// precedence over them), e.g. ACP_POSTGRES_URL -> POSTGRES_URL.
+	viper.SetEnvPrefix("ACP")
+	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	viper.AutomaticEnv()
+
+	// AutomaticEnv only resolves keys viper already knows about (from a
+	// config file, a bound flag, or an explicit BindEnv/SetDefault); it
+	// will not retroactively surface an arbitrary ACP_* var through
+	// viper.Unmarshal. Bind the keys data.Config unmarshals so
+	// ACP_POSTGRES_URL etc. work without a config file entry.
+	for _, key := range []string{
+		"POSTGRES_URL",
+		"POSTGRES_MAX_OPEN_CONNS",
+		"POSTGRES_MAX_IDLE_CONNS",
+		"POSTGRES_CONN_MAX_LIFETIME",
+	} {
+		if err := viper.BindEnv(key); err != nil {
+			logging.Log.WithError(err).WithField("key", key).Error("failed to bind env var")
+		}
+	}
+
+	// Setup Viper for JSON/YAML/TOML config, detected by extension.
+	viper.SetConfigName("config")
+	viper.AddConfigPath(".")
+	viper.AddConfigPath("$HOME/.adrons-core")
+	viper.AddConfigPath("/etc/adrons-core")
 
-	// Read the JSON config file
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			fmt.Println("No config.json file found. Please copy config.sample.json to config.json and modify as needed.")
+			fmt.Println("No config file found. Please copy config.sample.json to config.json and modify as needed.")
 		} else {
-			fmt.Printf("Error reading config file: %s\n", err)
+			logging.Log.WithError(err).Error("failed to read config file")
 		}
 	}
+
+	config.Global.LoadFromViper(viper.GetViper())
+
+	viper.OnConfigChange(func(e fsnotify.Event) {
+		config.Global.LoadFromViper(viper.GetViper())
+	})
+	viper.WatchConfig()
 }
@@ -1,14 +1,13 @@
 package cmd
 
 import (
-	"database/sql"
 	"fmt"
-	"strings"
+	"os"
 	"time"
 
-	_ "github.com/lib/pq"
+	"github.com/Adron/adrons-core-platform-cli/internal/logging"
+	"github.com/Adron/adrons-core-platform-cli/internal/output"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // dbCmd represents the db command
@@ -17,64 +16,38 @@ var dbCmd = &cobra.Command{
 	Short: "Display database connection information",
 	Long: `Display detailed information about the database connection including
 database name, connection properties, and other relevant details.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		postgresURL := viper.GetString("POSTGRES_URL")
-		if postgresURL == "" {
-			fmt.Println("Error: POSTGRES_URL is not set in configuration")
-			return
-		}
-
-		db, err := sql.Open("postgres", postgresURL)
+	PersistentPreRunE:  chainPreRunE(initLogging, requireDBClient),
+	PersistentPostRunE: closeDBClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := outputOptions(cmd)
 		if err != nil {
-			fmt.Printf("Error parsing connection details: %v\n", err)
-			return
-		}
-		defer db.Close()
-
-		fmt.Println("Database Connection Information:")
-		fmt.Println("-------------------------------")
-
-		// Get database name
-		var dbName string
-		err = db.QueryRow("SELECT current_database()").Scan(&dbName)
-		if err == nil {
-			fmt.Printf("Database Name: %s\n", dbName)
+			return err
 		}
 
-		// Get database version
-		var version string
-		err = db.QueryRow("SELECT version()").Scan(&version)
-		if err == nil {
-			fmt.Printf("Database Version: %s\n", version)
-		}
+		db := dbClient.DB
+		cfg := dbClient.Config
 
-		// Get current user
-		var user string
-		err = db.QueryRow("SELECT current_user").Scan(&user)
-		if err == nil {
-			fmt.Printf("Connected User: %s\n", user)
-		}
-
-		// Get server encoding
-		var encoding string
-		err = db.QueryRow("SHOW server_encoding").Scan(&encoding)
-		if err == nil {
-			fmt.Printf("Server Encoding: %s\n", encoding)
-		}
-
-		// Get timezone
-		var timezone string
-		err = db.QueryRow("SHOW timezone").Scan(&timezone)
-		if err == nil {
-			fmt.Printf("Timezone: %s\n", timezone)
+		rows := [][]string{}
+		add := func(property string, query string) {
+			var value string
+			if err := db.QueryRow(query).Scan(&value); err != nil {
+				logging.WithCommand(cmd).WithError(err).Warnf("failed to read %s", property)
+				return
+			}
+			rows = append(rows, []string{property, value})
 		}
-
-		// Parse and display connection string parts (safely)
-		fmt.Println("\nConnection String Properties:")
-		fmt.Println("----------------------------")
-		fmt.Printf("SSL Mode: %s\n", getSSLMode(postgresURL))
-		fmt.Printf("Host: %s\n", getHost(postgresURL))
-		fmt.Printf("Port: %s\n", getPort(postgresURL))
+		add("Database Name", "SELECT current_database()")
+		add("Database Version", "SELECT version()")
+		add("Connected User", "SELECT current_user")
+		add("Server Encoding", "SHOW server_encoding")
+		add("Timezone", "SHOW timezone")
+		rows = append(rows,
+			[]string{"SSL Mode", cfg.SSLMode},
+			[]string{"Host", cfg.Host},
+			[]string{"Port", cfg.Port},
+		)
+
+		return output.Render(os.Stdout, []string{"PROPERTY", "VALUE"}, rows, opts)
 	},
 }
 
@@ -84,22 +57,16 @@ var dbTablesCmd = &cobra.Command{
 	Short: "List all tables in the database",
 	Long: `Display a list of all tables in the connected database.
 This includes both public and system tables.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		postgresURL := viper.GetString("POSTGRES_URL")
-		if postgresURL == "" {
-			fmt.Println("Error: POSTGRES_URL is not set in configuration")
-			return
-		}
-
-		db, err := sql.Open("postgres", postgresURL)
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := outputOptions(cmd)
 		if err != nil {
-			fmt.Printf("Error connecting to database: %v\n", err)
-			return
+			return err
 		}
-		defer db.Close()
+
+		db := dbClient.DB
 
 		rows, err := db.Query(`
-			SELECT 
+			SELECT
 				table_schema,
 				table_name,
 				(SELECT count(*) FROM information_schema.columns WHERE table_name = t.table_name) as column_count
@@ -108,54 +75,50 @@ This includes both public and system tables.`,
 			ORDER BY table_schema, table_name;
 		`)
 		if err != nil {
-			fmt.Printf("Error querying tables: %v\n", err)
-			return
+			err = fmt.Errorf("querying tables: %w", err)
+			logging.WithCommand(cmd).WithError(err).Error("query failed")
+			return err
 		}
 		defer rows.Close()
 
-		fmt.Println("\nDatabase Tables:")
-		fmt.Println("----------------")
-		fmt.Printf("%-20s %-30s %s\n", "SCHEMA", "TABLE NAME", "COLUMNS")
-		fmt.Println(strings.Repeat("-", 60))
-
-		var count int
+		// A scan error on one row is logged and skipped rather than
+		// aborting the listing, so a single malformed row doesn't hide
+		// every other result. This holds for all four list commands below.
+		var result [][]string
 		for rows.Next() {
 			var schema, name string
 			var columnCount int
 			if err := rows.Scan(&schema, &name, &columnCount); err != nil {
-				fmt.Printf("Error scanning row: %v\n", err)
+				logging.WithCommand(cmd).WithError(err).Warn("skipping table row")
 				continue
 			}
-			fmt.Printf("%-20s %-30s %d\n", schema, name, columnCount)
-			count++
+			result = append(result, []string{schema, name, fmt.Sprintf("%d", columnCount)})
 		}
 
-		if count == 0 {
-			fmt.Println("No tables found in the public schema.")
-		} else {
-			fmt.Printf("\nTotal tables found: %d\n", count)
+		if err := output.Render(os.Stdout, []string{"SCHEMA", "TABLE NAME", "COLUMNS"}, result, opts); err != nil {
+			return err
+		}
+		if opts.Format == output.Table && !opts.Quiet {
+			fmt.Printf("\nTotal tables found: %d\n", len(result))
 		}
+		return nil
 	},
 }
 
 // tenantsCmd represents the tenants command
 var tenantsCmd = &cobra.Command{
-	Use:   "tenants",
-	Short: "List all tenants in the database",
-	Long:  `Display a list of all tenants stored in the database.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		postgresURL := viper.GetString("POSTGRES_URL")
-		if postgresURL == "" {
-			fmt.Println("Error: POSTGRES_URL is not set in configuration")
-			return
-		}
-
-		db, err := sql.Open("postgres", postgresURL)
+	Use:                "tenants",
+	Short:              "List all tenants in the database",
+	Long:               `Display a list of all tenants stored in the database.`,
+	PersistentPreRunE:  chainPreRunE(initLogging, requireDBClient),
+	PersistentPostRunE: closeDBClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := outputOptions(cmd)
 		if err != nil {
-			fmt.Printf("Error connecting to database: %v\n", err)
-			return
+			return err
 		}
-		defer db.Close()
+
+		db := dbClient.DB
 
 		rows, err := db.Query(`
 			SELECT id, name, created_at
@@ -163,176 +126,139 @@ var tenantsCmd = &cobra.Command{
 			ORDER BY name;
 		`)
 		if err != nil {
-			fmt.Printf("Error querying tenants: %v\n", err)
-			return
+			err = fmt.Errorf("querying tenants: %w", err)
+			logging.WithCommand(cmd).WithError(err).Error("query failed")
+			return err
 		}
 		defer rows.Close()
 
-		fmt.Println("\nTenants:")
-		fmt.Println("---------")
-		fmt.Printf("%-36s %-30s %-25s\n", "ID", "NAME", "CREATED AT")
-		fmt.Println(strings.Repeat("-", 91))
-
-		var count int
+		var result [][]string
 		for rows.Next() {
 			var id, name string
 			var createdAt time.Time
 			if err := rows.Scan(&id, &name, &createdAt); err != nil {
-				fmt.Printf("Error scanning row: %v\n", err)
+				logging.WithCommand(cmd).WithError(err).Warn("skipping tenant row")
 				continue
 			}
-			fmt.Printf("%-36s %-30s %-25s\n", id, name, createdAt.Format("2006-01-02 15:04:05"))
-			count++
+			result = append(result, []string{id, name, createdAt.Format("2006-01-02 15:04:05")})
 		}
 
-		if count == 0 {
-			fmt.Println("No tenants found.")
-		} else {
-			fmt.Printf("\nTotal tenants: %d\n", count)
+		if err := output.Render(os.Stdout, []string{"ID", "NAME", "CREATED AT"}, result, opts); err != nil {
+			return err
+		}
+		if opts.Format == output.Table && !opts.Quiet {
+			fmt.Printf("\nTotal tenants: %d\n", len(result))
 		}
+		return nil
 	},
 }
 
 // rolesCmd represents the roles command
 var rolesCmd = &cobra.Command{
-	Use:   "roles",
-	Short: "List all roles in the database",
-	Long:  `Display a list of all roles stored in the database.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		postgresURL := viper.GetString("POSTGRES_URL")
-		if postgresURL == "" {
-			fmt.Println("Error: POSTGRES_URL is not set in configuration")
-			return
+	Use:                "roles",
+	Short:              "List all roles in the database",
+	Long:               `Display a list of all roles stored in the database.`,
+	PersistentPreRunE:  chainPreRunE(initLogging, requireDBClient),
+	PersistentPostRunE: closeDBClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := outputOptions(cmd)
+		if err != nil {
+			return err
 		}
 
-		db, err := sql.Open("postgres", postgresURL)
+		db := dbClient.DB
+
+		clause, filterArgs, err := tenantFilter(cmd)
 		if err != nil {
-			fmt.Printf("Error connecting to database: %v\n", err)
-			return
+			return err
 		}
-		defer db.Close()
 
-		rows, err := db.Query(`
+		rows, err := db.Query(fmt.Sprintf(`
 			SELECT id, name, description, created_at
 			FROM roles
+			%s
 			ORDER BY name;
-		`)
+		`, clause), filterArgs...)
 		if err != nil {
-			fmt.Printf("Error querying roles: %v\n", err)
-			return
+			err = fmt.Errorf("querying roles: %w", err)
+			logging.WithCommand(cmd).WithError(err).Error("query failed")
+			return err
 		}
 		defer rows.Close()
 
-		fmt.Println("\nRoles:")
-		fmt.Println("------")
-		fmt.Printf("%-36s %-20s %-30s %-25s\n", "ID", "NAME", "DESCRIPTION", "CREATED AT")
-		fmt.Println(strings.Repeat("-", 111))
-
-		var count int
+		var result [][]string
 		for rows.Next() {
 			var id, name, description string
 			var createdAt time.Time
 			if err := rows.Scan(&id, &name, &description, &createdAt); err != nil {
-				fmt.Printf("Error scanning row: %v\n", err)
+				logging.WithCommand(cmd).WithError(err).Warn("skipping role row")
 				continue
 			}
-			fmt.Printf("%-36s %-20s %-30s %-25s\n", id, name, description, createdAt.Format("2006-01-02 15:04:05"))
-			count++
+			result = append(result, []string{id, name, description, createdAt.Format("2006-01-02 15:04:05")})
 		}
 
-		if count == 0 {
-			fmt.Println("No roles found.")
-		} else {
-			fmt.Printf("\nTotal roles: %d\n", count)
+		if err := output.Render(os.Stdout, []string{"ID", "NAME", "DESCRIPTION", "CREATED AT"}, result, opts); err != nil {
+			return err
+		}
+		if opts.Format == output.Table && !opts.Quiet {
+			fmt.Printf("\nTotal roles: %d\n", len(result))
 		}
+		return nil
 	},
 }
 
 // usersCmd represents the users command
 var usersCmd = &cobra.Command{
-	Use:   "users",
-	Short: "List all users in the database",
-	Long:  `Display a list of all users stored in the database.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		postgresURL := viper.GetString("POSTGRES_URL")
-		if postgresURL == "" {
-			fmt.Println("Error: POSTGRES_URL is not set in configuration")
-			return
+	Use:                "users",
+	Short:              "List all users in the database",
+	Long:               `Display a list of all users stored in the database.`,
+	PersistentPreRunE:  chainPreRunE(initLogging, requireDBClient),
+	PersistentPostRunE: closeDBClient,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := outputOptions(cmd)
+		if err != nil {
+			return err
 		}
 
-		db, err := sql.Open("postgres", postgresURL)
+		db := dbClient.DB
+
+		clause, filterArgs, err := tenantFilter(cmd)
 		if err != nil {
-			fmt.Printf("Error connecting to database: %v\n", err)
-			return
+			return err
 		}
-		defer db.Close()
 
-		rows, err := db.Query(`
+		rows, err := db.Query(fmt.Sprintf(`
 			SELECT id, username, email, created_at
 			FROM users
+			%s
 			ORDER BY username;
-		`)
+		`, clause), filterArgs...)
 		if err != nil {
-			fmt.Printf("Error querying users: %v\n", err)
-			return
+			err = fmt.Errorf("querying users: %w", err)
+			logging.WithCommand(cmd).WithError(err).Error("query failed")
+			return err
 		}
 		defer rows.Close()
 
-		fmt.Println("\nUsers:")
-		fmt.Println("------")
-		fmt.Printf("%-36s %-20s %-30s %-25s\n", "ID", "USERNAME", "EMAIL", "CREATED AT")
-		fmt.Println(strings.Repeat("-", 111))
-
-		var count int
+		var result [][]string
 		for rows.Next() {
 			var id, username, email string
 			var createdAt time.Time
 			if err := rows.Scan(&id, &username, &email, &createdAt); err != nil {
-				fmt.Printf("Error scanning row: %v\n", err)
+				logging.WithCommand(cmd).WithError(err).Warn("skipping user row")
 				continue
 			}
-			fmt.Printf("%-36s %-20s %-30s %-25s\n", id, username, email, createdAt.Format("2006-01-02 15:04:05"))
-			count++
+			result = append(result, []string{id, username, email, createdAt.Format("2006-01-02 15:04:05")})
 		}
 
-		if count == 0 {
-			fmt.Println("No users found.")
-		} else {
-			fmt.Printf("\nTotal users: %d\n", count)
+		if err := output.Render(os.Stdout, []string{"ID", "USERNAME", "EMAIL", "CREATED AT"}, result, opts); err != nil {
+			return err
 		}
-	},
-}
-
-// Helper functions for parsing connection strings
-func getSSLMode(connStr string) string {
-	if strings.Contains(connStr, "sslmode=") {
-		parts := strings.Split(connStr, "sslmode=")
-		if len(parts) > 1 {
-			return strings.Split(parts[1], "&")[0]
-		}
-	}
-	return "not specified"
-}
-
-func getHost(connStr string) string {
-	if strings.Contains(connStr, "@") {
-		parts := strings.Split(connStr, "@")
-		if len(parts) > 1 {
-			hostPort := strings.Split(parts[1], "/")[0]
-			return strings.Split(hostPort, ":")[0]
+		if opts.Format == output.Table && !opts.Quiet {
+			fmt.Printf("\nTotal users: %d\n", len(result))
 		}
-	}
-	return "not specified"
-}
-
-func getPort(connStr string) string {
-	if strings.Contains(connStr, ":") {
-		parts := strings.Split(connStr, ":")
-		if len(parts) > 2 {
-			return strings.Split(parts[2], "/")[0]
-		}
-	}
-	return "5432 (default)"
+		return nil
+	},
 }
 
 func init() {
@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Adron/adrons-core-platform-cli/internal/migrations"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/golang-migrate/migrate/v4/database/postgres"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/spf13/cobra"
+)
+
+// migrateCmd represents the db migrate command
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "Manage schema migrations for the tenants, roles and users tables",
+	Long: `Apply, roll back and inspect the schema migrations that create and
+evolve the tenants, roles and users tables the rest of the CLI assumes
+already exist.`,
+}
+
+// migrateUpCmd applies pending migrations.
+var migrateUpCmd = &cobra.Command{
+	Use:   "up [N]",
+	Short: "Apply all pending migrations, or the next N if given",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		m, err := newMigrator()
+		if err != nil {
+			return err
+		}
+		defer m.Close()
+
+		if len(args) == 1 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid step count %q: %w", args[0], err)
+			}
+			err = m.Steps(n)
+		} else {
+			err = m.Up()
+		}
+		return reportMigrateResult("up", err)
+	},
+}
+
+// migrateDownCmd rolls back migrations.
+var migrateDownCmd = &cobra.Command{
+	Use:   "down [N]",
+	Short: "Roll back all migrations, or the previous N if given",
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		m, err := newMigrator()
+		if err != nil {
+			return err
+		}
+		defer m.Close()
+
+		if len(args) == 1 {
+			n, err := strconv.Atoi(args[0])
+			if err != nil {
+				return fmt.Errorf("invalid step count %q: %w", args[0], err)
+			}
+			err = m.Steps(-n)
+		} else {
+			err = m.Down()
+		}
+		return reportMigrateResult("down", err)
+	},
+}
+
+// migrateStatusCmd shows the currently applied migration version and
+// lists which migrations from internal/migrations/sql are still pending.
+var migrateStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the applied and pending migration versions",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		all, err := allMigrationVersions()
+		if err != nil {
+			return err
+		}
+
+		m, err := newMigrator()
+		if err != nil {
+			return err
+		}
+		defer m.Close()
+
+		version, dirty, err := m.Version()
+		if errors.Is(err, migrate.ErrNilVersion) {
+			fmt.Println("No migrations applied yet.")
+			printPendingMigrations(all, 0)
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading migration status: %w", err)
+		}
+
+		fmt.Printf("Applied version: %d\n", version)
+		if dirty {
+			fmt.Println("State: dirty (a previous migration failed partway through; use 'db migrate force <version>')")
+		} else {
+			fmt.Println("State: clean")
+		}
+		printPendingMigrations(all, version)
+		return nil
+	},
+}
+
+// migrateForceCmd marks the schema_migrations table as being at a given
+// version without running any migration, for recovering from a dirty state.
+var migrateForceCmd = &cobra.Command{
+	Use:   "force <version>",
+	Short: "Set the migration version without running migrations",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		version, err := strconv.Atoi(args[0])
+		if err != nil {
+			return fmt.Errorf("invalid version %q: %w", args[0], err)
+		}
+
+		m, err := newMigrator()
+		if err != nil {
+			return err
+		}
+		defer m.Close()
+
+		if err := m.Force(version); err != nil {
+			return fmt.Errorf("forcing version %d: %w", version, err)
+		}
+		fmt.Printf("Forced migration version to %d\n", version)
+		return nil
+	},
+}
+
+// migrateCreateCmd scaffolds a new pair of .up.sql/.down.sql files.
+var migrateCreateCmd = &cobra.Command{
+	Use:   "create <name>",
+	Short: "Scaffold a new pair of up/down migration files",
+	Args:  cobra.ExactArgs(1),
+	// Scaffolding new files doesn't need a database connection.
+	PersistentPreRunE: initLogging,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := strings.ReplaceAll(strings.TrimSpace(args[0]), " ", "_")
+		if name == "" {
+			return fmt.Errorf("migration name must not be empty")
+		}
+
+		dir, err := migrationsSourceDir()
+		if err != nil {
+			return err
+		}
+		version, err := nextMigrationVersion(dir)
+		if err != nil {
+			return err
+		}
+
+		base := fmt.Sprintf("%04d_%s", version, name)
+		upPath := filepath.Join(dir, base+".up.sql")
+		downPath := filepath.Join(dir, base+".down.sql")
+
+		if err := os.WriteFile(upPath, []byte("-- "+name+" up migration\n"), 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", upPath, err)
+		}
+		if err := os.WriteFile(downPath, []byte("-- "+name+" down migration\n"), 0o644); err != nil {
+			return fmt.Errorf("writing %s: %w", downPath, err)
+		}
+
+		fmt.Printf("Created %s\n", upPath)
+		fmt.Printf("Created %s\n", downPath)
+		return nil
+	},
+}
+
+// newMigrator builds a migrate.Migrate backed by the shared dbClient
+// connection pool and the embedded SQL migration files.
+func newMigrator() (*migrate.Migrate, error) {
+	srcDriver, err := iofs.New(migrations.FS, migrations.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("loading embedded migrations: %w", err)
+	}
+
+	dbDriver, err := postgres.WithInstance(dbClient.DB, &postgres.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("creating migration driver: %w", err)
+	}
+
+	m, err := migrate.NewWithInstance("iofs", srcDriver, "postgres", dbDriver)
+	if err != nil {
+		return nil, fmt.Errorf("initializing migrator: %w", err)
+	}
+	return m, nil
+}
+
+// reportMigrateResult turns migrate.ErrNoChange into a friendly message
+// instead of an error, since "nothing to do" isn't a failure.
+func reportMigrateResult(direction string, err error) error {
+	if errors.Is(err, migrate.ErrNoChange) {
+		fmt.Println("No migrations to apply; already up to date.")
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("migrating %s: %w", direction, err)
+	}
+	fmt.Printf("Migrated %s successfully.\n", direction)
+	return nil
+}
+
+// migrationsSourceDir locates internal/migrations/sql on disk so new
+// migration files land where go:embed will pick them up, regardless of
+// the directory `acp` was invoked from. migrations.FS only exposes the
+// files baked in at build time, so `create` has to find the source tree
+// itself; it does that by walking up from the current directory looking
+// for the module's go.mod.
+func migrationsSourceDir() (string, error) {
+	start, err := os.Getwd()
+	if err != nil {
+		return "", fmt.Errorf("getting working directory: %w", err)
+	}
+
+	for dir := start; ; {
+		if data, err := os.ReadFile(filepath.Join(dir, "go.mod")); err == nil {
+			if strings.Contains(string(data), "module "+migrations.ModulePath) {
+				return filepath.Join(dir, "internal", "migrations", migrations.Dir), nil
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("could not locate the %s checkout (no go.mod found in %q or any parent directory); run 'db migrate create' from within the repo", migrations.ModulePath, start)
+		}
+		dir = parent
+	}
+}
+
+// nextMigrationVersion scans dir for existing "NNNN_*" entries (up and
+// down files alike) and returns one past the highest version found.
+func nextMigrationVersion(dir string) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return 0, fmt.Errorf("reading migrations directory %s: %w", dir, err)
+	}
+
+	var versions []int
+	for _, entry := range entries {
+		parts := strings.SplitN(entry.Name(), "_", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		if v, err := strconv.Atoi(parts[0]); err == nil {
+			versions = append(versions, v)
+		}
+	}
+
+	if len(versions) == 0 {
+		return 1, nil
+	}
+	sort.Ints(versions)
+	return versions[len(versions)-1] + 1, nil
+}
+
+// migrationFile identifies one embedded migration by version and name,
+// as parsed from its "NNNN_name.up.sql" filename.
+type migrationFile struct {
+	version int
+	name    string
+}
+
+// allMigrationVersions lists every migration embedded in migrations.FS,
+// sorted by version.
+func allMigrationVersions() ([]migrationFile, error) {
+	entries, err := migrations.FS.ReadDir(migrations.Dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading embedded migrations: %w", err)
+	}
+
+	var files []migrationFile
+	for _, entry := range entries {
+		name := entry.Name()
+		if !strings.HasSuffix(name, ".up.sql") {
+			continue
+		}
+		parts := strings.SplitN(strings.TrimSuffix(name, ".up.sql"), "_", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		version, err := strconv.Atoi(parts[0])
+		if err != nil {
+			continue
+		}
+		files = append(files, migrationFile{version: version, name: parts[1]})
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].version < files[j].version })
+	return files, nil
+}
+
+// printPendingMigrations prints the migrations in all whose version is
+// greater than the currently applied version.
+func printPendingMigrations(all []migrationFile, version uint) {
+	var pending []migrationFile
+	for _, f := range all {
+		if uint(f.version) > version {
+			pending = append(pending, f)
+		}
+	}
+
+	if len(pending) == 0 {
+		fmt.Println("Pending: none, up to date.")
+		return
+	}
+
+	fmt.Println("Pending:")
+	for _, f := range pending {
+		fmt.Printf("  %04d_%s\n", f.version, f.name)
+	}
+}
+
+func init() {
+	dbCmd.AddCommand(migrateCmd)
+	migrateCmd.AddCommand(migrateUpCmd)
+	migrateCmd.AddCommand(migrateDownCmd)
+	migrateCmd.AddCommand(migrateStatusCmd)
+	migrateCmd.AddCommand(migrateForceCmd)
+	migrateCmd.AddCommand(migrateCreateCmd)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestNextMigrationVersionEmptyDir(t *testing.T) {
+	version, err := nextMigrationVersion(t.TempDir())
+	if err != nil {
+		t.Fatalf("nextMigrationVersion returned error: %v", err)
+	}
+	if version != 1 {
+		t.Errorf("nextMigrationVersion(empty) = %d, want 1", version)
+	}
+}
+
+func TestNextMigrationVersionExistingFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"0001_create_tenants_table.up.sql",
+		"0001_create_tenants_table.down.sql",
+		"0003_add_tenant_description.up.sql",
+		"0003_add_tenant_description.down.sql",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatalf("writing fixture %s: %v", name, err)
+		}
+	}
+
+	version, err := nextMigrationVersion(dir)
+	if err != nil {
+		t.Fatalf("nextMigrationVersion returned error: %v", err)
+	}
+	if version != 4 {
+		t.Errorf("nextMigrationVersion = %d, want 4", version)
+	}
+}
+
+func TestAllMigrationVersionsSortedAndParsed(t *testing.T) {
+	all, err := allMigrationVersions()
+	if err != nil {
+		t.Fatalf("allMigrationVersions returned error: %v", err)
+	}
+
+	if len(all) == 0 {
+		t.Fatal("allMigrationVersions returned no migrations")
+	}
+
+	for i := 1; i < len(all); i++ {
+		if all[i].version <= all[i-1].version {
+			t.Errorf("migrations not sorted by version: %d came after %d", all[i].version, all[i-1].version)
+		}
+	}
+
+	if all[0].version != 1 || all[0].name != "create_tenants_table" {
+		t.Errorf("all[0] = %+v, want version 1 named create_tenants_table", all[0])
+	}
+}
@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"database/sql"
+	"fmt"
+	"os"
+
+	"github.com/Adron/adrons-core-platform-cli/internal/config"
+	"github.com/Adron/adrons-core-platform-cli/internal/logging"
+	"github.com/Adron/adrons-core-platform-cli/internal/output"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// tenantCreateCmd represents the tenants create subcommand
+var tenantCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a new tenant",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, err := cmd.Flags().GetString("name")
+		if err != nil {
+			return err
+		}
+		if name == "" {
+			return fmt.Errorf("--name is required")
+		}
+		description, err := cmd.Flags().GetString("description")
+		if err != nil {
+			return err
+		}
+
+		var id string
+		err = dbClient.DB.QueryRow(`
+			INSERT INTO tenants (name, description)
+			VALUES ($1, $2)
+			RETURNING id;
+		`, name, description).Scan(&id)
+		if err != nil {
+			err = fmt.Errorf("creating tenant: %w", err)
+			logging.WithCommand(cmd).WithError(err).Error("insert failed")
+			return err
+		}
+
+		fmt.Printf("Created tenant %s (%s)\n", name, id)
+		return nil
+	},
+}
+
+// tenantDeleteCmd represents the tenants delete subcommand
+var tenantDeleteCmd = &cobra.Command{
+	Use:   "delete <id>",
+	Short: "Delete a tenant",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+
+		result, err := dbClient.DB.Exec(`DELETE FROM tenants WHERE id = $1;`, id)
+		if err != nil {
+			err = fmt.Errorf("deleting tenant: %w", err)
+			logging.WithCommand(cmd).WithError(err).Error("delete failed")
+			return err
+		}
+
+		if affected, err := result.RowsAffected(); err == nil && affected == 0 {
+			return fmt.Errorf("no tenant found with id %q", id)
+		}
+
+		fmt.Printf("Deleted tenant %s\n", id)
+		return nil
+	},
+}
+
+// tenantShowCmd represents the tenants show subcommand
+var tenantShowCmd = &cobra.Command{
+	Use:   "show <id>",
+	Short: "Show a tenant's details along with its user and role counts",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		opts, err := outputOptions(cmd)
+		if err != nil {
+			return err
+		}
+		id := args[0]
+
+		var name, description string
+		var userCount, roleCount int
+		err = dbClient.DB.QueryRow(`
+			SELECT
+				t.name,
+				t.description,
+				(SELECT count(*) FROM users u WHERE u.tenant_id = t.id) AS user_count,
+				(SELECT count(*) FROM roles r WHERE r.tenant_id = t.id) AS role_count
+			FROM tenants t
+			WHERE t.id = $1;
+		`, id).Scan(&name, &description, &userCount, &roleCount)
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("no tenant found with id %q", id)
+		}
+		if err != nil {
+			err = fmt.Errorf("querying tenant: %w", err)
+			logging.WithCommand(cmd).WithError(err).Error("query failed")
+			return err
+		}
+
+		headers := []string{"ID", "NAME", "DESCRIPTION", "USERS", "ROLES"}
+		row := []string{id, name, description, fmt.Sprintf("%d", userCount), fmt.Sprintf("%d", roleCount)}
+		return output.Render(os.Stdout, headers, [][]string{row}, opts)
+	},
+}
+
+// tenantSwitchCmd represents the tenants switch subcommand
+var tenantSwitchCmd = &cobra.Command{
+	Use:   "switch <id>",
+	Short: "Set the current tenant that 'users' and 'roles' scope their queries to",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+
+		var exists bool
+		err := dbClient.DB.QueryRow(`SELECT EXISTS(SELECT 1 FROM tenants WHERE id = $1);`, id).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("checking tenant: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("no tenant found with id %q", id)
+		}
+
+		path := configFilePath()
+		settings, err := config.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		settings["CURRENT_TENANT"] = id
+		if err := config.WriteFileAtomic(path, settings); err != nil {
+			return err
+		}
+
+		viper.Set("CURRENT_TENANT", id)
+		config.Global.SetCurrentTenant(id)
+
+		fmt.Printf("Switched current tenant to %s\n", id)
+		return nil
+	},
+}
+
+// tenantFilter returns the "WHERE tenant_id = $1" clause and its args to
+// scope a roles/users query to the current tenant, unless --all-tenants
+// was passed or no tenant has been selected via 'tenants switch'.
+func tenantFilter(cmd *cobra.Command) (string, []interface{}, error) {
+	allTenants, err := cmd.Flags().GetBool("all-tenants")
+	if err != nil {
+		return "", nil, err
+	}
+	if allTenants {
+		return "", nil, nil
+	}
+
+	tenantID := config.Global.CurrentTenant()
+	if tenantID == "" {
+		return "", nil, nil
+	}
+
+	return "WHERE tenant_id = $1", []interface{}{tenantID}, nil
+}
+
+func init() {
+	tenantsCmd.AddCommand(tenantCreateCmd)
+	tenantsCmd.AddCommand(tenantDeleteCmd)
+	tenantsCmd.AddCommand(tenantShowCmd)
+	tenantsCmd.AddCommand(tenantSwitchCmd)
+
+	tenantCreateCmd.Flags().String("name", "", "Name of the new tenant")
+	tenantCreateCmd.Flags().String("description", "", "Description of the new tenant")
+
+	rolesCmd.Flags().Bool("all-tenants", false, "Ignore the current tenant and list roles across every tenant")
+	usersCmd.Flags().Bool("all-tenants", false, "Ignore the current tenant and list users across every tenant")
+}
@@ -0,0 +1,103 @@
+/*
+Copyright © 2019 Adron Hall <adron@thrashingcode.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+// ReadFile loads the settings stored at path, choosing a decoder based on
+// its extension. A missing file is not an error; it yields an empty map
+// so add/update/delete can create the file on first write.
+func ReadFile(path string) (map[string]interface{}, error) {
+	settings := map[string]interface{}{}
+
+	raw, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return settings, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+	if len(raw) == 0 {
+		return settings, nil
+	}
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(raw, &settings)
+	case ".toml":
+		err = toml.Unmarshal(raw, &settings)
+	default:
+		err = json.Unmarshal(raw, &settings)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return settings, nil
+}
+
+// WriteFileAtomic encodes settings per path's extension (json/yaml/toml,
+// defaulting to json) and writes it to path via a temp file + rename so
+// a crash mid-write can never leave a truncated config behind.
+func WriteFileAtomic(path string, settings map[string]interface{}) error {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		data, err = yaml.Marshal(settings)
+	case ".toml":
+		data, err = toml.Marshal(settings)
+	default:
+		data, err = json.MarshalIndent(settings, "", "  ")
+	}
+	if err != nil {
+		return fmt.Errorf("encoding %s: %w", path, err)
+	}
+
+	dir := filepath.Dir(path)
+	tmp, err := os.CreateTemp(dir, ".config-*.tmp")
+	if err != nil {
+		return fmt.Errorf("creating temp file in %s: %w", dir, err)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath) // no-op once the rename below succeeds
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing %s: %w", tmpPath, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("closing %s: %w", tmpPath, err)
+	}
+
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("renaming %s to %s: %w", tmpPath, path, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,53 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadFileMissingReturnsEmptyMap(t *testing.T) {
+	settings, err := ReadFile(filepath.Join(t.TempDir(), "does-not-exist.json"))
+	if err != nil {
+		t.Fatalf("ReadFile returned error for missing file: %v", err)
+	}
+	if len(settings) != 0 {
+		t.Errorf("ReadFile(missing) = %v, want empty map", settings)
+	}
+}
+
+func TestWriteFileAtomicThenReadFileRoundTrips(t *testing.T) {
+	for _, ext := range []string{".json", ".yaml", ".toml"} {
+		t.Run(ext, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config"+ext)
+			want := map[string]interface{}{"POSTGRES_URL": "postgres://localhost/db"}
+
+			if err := WriteFileAtomic(path, want); err != nil {
+				t.Fatalf("WriteFileAtomic: %v", err)
+			}
+
+			got, err := ReadFile(path)
+			if err != nil {
+				t.Fatalf("ReadFile: %v", err)
+			}
+			if got["POSTGRES_URL"] != want["POSTGRES_URL"] {
+				t.Errorf("ReadFile(%s) = %v, want %v", ext, got, want)
+			}
+		})
+	}
+}
+
+func TestReadFileEmptyFileReturnsEmptyMap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, nil, 0o644); err != nil {
+		t.Fatalf("writing empty file: %v", err)
+	}
+
+	settings, err := ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile: %v", err)
+	}
+	if len(settings) != 0 {
+		t.Errorf("ReadFile(empty) = %v, want empty map", settings)
+	}
+}
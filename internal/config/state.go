@@ -0,0 +1,111 @@
+/*
+Copyright © 2019 Adron Hall <adron@thrashingcode.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config holds the application's in-memory view of its own
+// settings. State is a single generated-style struct with typed
+// getters/setters so the rest of the CLI stops reaching into viper
+// directly and instead goes through a value that is safe to read and
+// reload concurrently.
+package config
+
+import (
+	"sync"
+
+	"github.com/spf13/viper"
+)
+
+// State is the typed, concurrency-safe view of the CLI's own
+// configuration. It is rebuilt from viper whenever the config file
+// changes on disk.
+type State struct {
+	mu sync.RWMutex
+
+	postgresURL   string
+	username      string
+	debug         bool
+	currentTenant string
+}
+
+// Global is the shared State instance used across commands.
+var Global = &State{}
+
+// LoadFromViper populates s from the current viper settings.
+func (s *State) LoadFromViper(v *viper.Viper) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.postgresURL = v.GetString("POSTGRES_URL")
+	s.username = v.GetString("USERNAME")
+	s.debug = v.GetBool("DEBUG")
+	s.currentTenant = v.GetString("CURRENT_TENANT")
+}
+
+// PostgresURL returns the configured Postgres connection string.
+func (s *State) PostgresURL() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.postgresURL
+}
+
+// SetPostgresURL updates the in-memory Postgres connection string.
+func (s *State) SetPostgresURL(url string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.postgresURL = url
+}
+
+// Username returns the configured username.
+func (s *State) Username() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.username
+}
+
+// SetUsername updates the in-memory username.
+func (s *State) SetUsername(username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.username = username
+}
+
+// CurrentTenant returns the ID of the tenant commands should scope
+// their queries to, or "" if none has been selected via 'tenants switch'.
+func (s *State) CurrentTenant() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.currentTenant
+}
+
+// SetCurrentTenant updates the in-memory current tenant ID.
+func (s *State) SetCurrentTenant(tenantID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.currentTenant = tenantID
+}
+
+// Debug returns whether debug mode is enabled.
+func (s *State) Debug() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.debug
+}
+
+// SetDebug updates the in-memory debug flag.
+func (s *State) SetDebug(debug bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.debug = debug
+}
@@ -0,0 +1,79 @@
+/*
+Copyright © 2019 Adron Hall <adron@thrashingcode.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package data
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/lib/pq"
+	"github.com/spf13/viper"
+)
+
+// Client wraps a single shared *sql.DB connection pool along with the
+// Config it was built from, so commands no longer have to open their
+// own connection.
+type Client struct {
+	DB     *sql.DB
+	Config Config
+}
+
+// NewClientFromViper unmarshals the current viper settings into a Config,
+// parses the connection fields out of POSTGRES_URL, and opens a pooled
+// connection.
+func NewClientFromViper() (*Client, error) {
+	var cfg Config
+	if err := viper.Unmarshal(&cfg); err != nil {
+		return nil, fmt.Errorf("unmarshaling database configuration: %w", err)
+	}
+	if err := cfg.Parse(); err != nil {
+		return nil, err
+	}
+	return NewClient(cfg)
+}
+
+// NewClient opens a pooled connection using cfg.
+func NewClient(cfg Config) (*Client, error) {
+	db, err := sql.Open("postgres", cfg.PostgresURL)
+	if err != nil {
+		return nil, fmt.Errorf("opening database connection: %w", err)
+	}
+
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	return &Client{DB: db, Config: cfg}, nil
+}
+
+// Ping verifies the connection is reachable, failing fast with a clear
+// error rather than letting the first query surface a confusing one.
+func (c *Client) Ping() error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if err := c.DB.PingContext(ctx); err != nil {
+		return fmt.Errorf("pinging database at %s:%s: %w", c.Config.Host, c.Config.Port, err)
+	}
+	return nil
+}
+
+// Close closes the underlying connection pool.
+func (c *Client) Close() error {
+	return c.DB.Close()
+}
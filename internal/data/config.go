@@ -0,0 +1,91 @@
+/*
+Copyright © 2019 Adron Hall <adron@thrashingcode.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package data
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMaxOpenConns    = 10
+	defaultMaxIdleConns    = 5
+	defaultConnMaxLifetime = 30 * time.Minute
+	defaultPort            = "5432"
+)
+
+// Config is the strongly-typed database configuration used to build a
+// Client. PostgresURL is read directly from viper; the remaining
+// connection fields are derived from it so callers no longer have to
+// reach for ad-hoc string parsing.
+type Config struct {
+	PostgresURL string `mapstructure:"POSTGRES_URL"`
+
+	Host     string
+	Port     string
+	User     string
+	Password string
+	Database string
+	SSLMode  string
+
+	MaxOpenConns    int           `mapstructure:"POSTGRES_MAX_OPEN_CONNS"`
+	MaxIdleConns    int           `mapstructure:"POSTGRES_MAX_IDLE_CONNS"`
+	ConnMaxLifetime time.Duration `mapstructure:"POSTGRES_CONN_MAX_LIFETIME"`
+}
+
+// Parse fills in the Host, Port, User, Password, Database and SSLMode
+// fields by parsing PostgresURL, and applies defaults for any pool
+// tuning fields left unset. It replaces the old getHost/getPort/getSSLMode
+// string parsers.
+func (c *Config) Parse() error {
+	if c.PostgresURL == "" {
+		return fmt.Errorf("POSTGRES_URL is not set in configuration")
+	}
+
+	u, err := url.Parse(c.PostgresURL)
+	if err != nil {
+		return fmt.Errorf("parsing POSTGRES_URL: %w", err)
+	}
+
+	c.Host = u.Hostname()
+	if c.Port = u.Port(); c.Port == "" {
+		c.Port = defaultPort
+	}
+	if u.User != nil {
+		c.User = u.User.Username()
+		c.Password, _ = u.User.Password()
+	}
+	c.Database = strings.TrimPrefix(u.Path, "/")
+	if sslMode := u.Query().Get("sslmode"); sslMode != "" {
+		c.SSLMode = sslMode
+	} else {
+		c.SSLMode = "not specified"
+	}
+
+	if c.MaxOpenConns == 0 {
+		c.MaxOpenConns = defaultMaxOpenConns
+	}
+	if c.MaxIdleConns == 0 {
+		c.MaxIdleConns = defaultMaxIdleConns
+	}
+	if c.ConnMaxLifetime == 0 {
+		c.ConnMaxLifetime = defaultConnMaxLifetime
+	}
+
+	return nil
+}
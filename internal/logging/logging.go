@@ -0,0 +1,67 @@
+/*
+Copyright © 2019 Adron Hall <adron@thrashingcode.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logging provides the structured logger shared by every
+// command, replacing ad-hoc fmt.Println/fmt.Printf error reporting.
+package logging
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+// Log is the shared logger every command reports through.
+var Log = logrus.New()
+
+// Init configures Log's level, formatter and output according to the
+// --log-level, --log-format and --log-file flags (or their ACP_LOG_*
+// env var / config file equivalents).
+func Init(level, format, file string) error {
+	lvl, err := logrus.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid --log-level %q: %w", level, err)
+	}
+	Log.SetLevel(lvl)
+
+	switch format {
+	case "json":
+		Log.SetFormatter(&logrus.JSONFormatter{})
+	case "text", "":
+		Log.SetFormatter(&logrus.TextFormatter{FullTimestamp: true})
+	default:
+		return fmt.Errorf("invalid --log-format %q (want text or json)", format)
+	}
+
+	if file != "" {
+		f, err := os.OpenFile(file, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+		if err != nil {
+			return fmt.Errorf("opening --log-file %s: %w", file, err)
+		}
+		Log.SetOutput(f)
+	}
+
+	return nil
+}
+
+// WithCommand returns a log entry tagged with the full cobra command
+// path (e.g. "acp db tables"), so log lines can be traced back to the
+// subcommand that produced them.
+func WithCommand(cmd *cobra.Command) *logrus.Entry {
+	return Log.WithField("command", cmd.CommandPath())
+}
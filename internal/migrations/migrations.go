@@ -0,0 +1,34 @@
+/*
+Copyright © 2019 Adron Hall <adron@thrashingcode.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package migrations embeds the SQL migration files that create and
+// evolve the tenants, roles and users tables the rest of the CLI
+// assumes already exist.
+package migrations
+
+import "embed"
+
+//go:embed sql/*.sql
+var FS embed.FS
+
+// Dir is the directory inside FS (and on disk, for `db migrate create`)
+// that holds the migration files.
+const Dir = "sql"
+
+// ModulePath identifies this module's go.mod, so `db migrate create` can
+// find internal/migrations/sql on disk when run from outside the repo
+// root.
+const ModulePath = "github.com/Adron/adrons-core-platform-cli"
@@ -0,0 +1,134 @@
+/*
+Copyright © 2019 Adron Hall <adron@thrashingcode.com>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+	http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package output renders the rows produced by list commands (tenants,
+// roles, users, db tables, ...) in a format a human or a script can
+// consume, so every command stops hand-computing column widths.
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is a machine-readable output mode selected via --output/-o.
+type Format string
+
+const (
+	Table Format = "table"
+	JSON  Format = "json"
+	YAML  Format = "yaml"
+	CSV   Format = "csv"
+)
+
+// ParseFormat validates a --output flag value, defaulting an empty
+// string to Table.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(s); f {
+	case "":
+		return Table, nil
+	case Table, JSON, YAML, CSV:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported output format %q (want table, json, yaml or csv)", s)
+	}
+}
+
+// Options controls rendering behavior shared by every list command.
+type Options struct {
+	Format   Format
+	Quiet    bool
+	NoHeader bool
+}
+
+// Render writes rows (each a slice of column values ordered to match
+// headers) to w according to opts.Format.
+func Render(w io.Writer, headers []string, rows [][]string, opts Options) error {
+	switch opts.Format {
+	case JSON:
+		return renderJSON(w, headers, rows)
+	case YAML:
+		return renderYAML(w, headers, rows)
+	case CSV:
+		return renderCSV(w, headers, rows, opts)
+	default:
+		return renderTable(w, headers, rows, opts)
+	}
+}
+
+func renderTable(w io.Writer, headers []string, rows [][]string, opts Options) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if !opts.NoHeader && !opts.Quiet {
+		fmt.Fprintln(tw, strings.Join(headers, "\t"))
+	}
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+func renderCSV(w io.Writer, headers []string, rows [][]string, opts Options) error {
+	cw := csv.NewWriter(w)
+	if !opts.NoHeader && !opts.Quiet {
+		if err := cw.Write(headers); err != nil {
+			return err
+		}
+	}
+	for _, row := range rows {
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func renderJSON(w io.Writer, headers []string, rows [][]string) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(toMaps(headers, rows))
+}
+
+func renderYAML(w io.Writer, headers []string, rows [][]string) error {
+	data, err := yaml.Marshal(toMaps(headers, rows))
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// toMaps pairs each row with headers so JSON/YAML output carries field
+// names instead of bare arrays.
+func toMaps(headers []string, rows [][]string) []map[string]string {
+	out := make([]map[string]string, 0, len(rows))
+	for _, row := range rows {
+		m := make(map[string]string, len(headers))
+		for i, h := range headers {
+			if i < len(row) {
+				m[h] = row[i]
+			}
+		}
+		out = append(out, m)
+	}
+	return out
+}
@@ -0,0 +1,130 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestParseFormat(t *testing.T) {
+	cases := []struct {
+		in      string
+		want    Format
+		wantErr bool
+	}{
+		{"", Table, false},
+		{"table", Table, false},
+		{"json", JSON, false},
+		{"yaml", YAML, false},
+		{"csv", CSV, false},
+		{"xml", "", true},
+	}
+
+	for _, tc := range cases {
+		got, err := ParseFormat(tc.in)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("ParseFormat(%q) = %q, nil; want error", tc.in, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseFormat(%q) returned unexpected error: %v", tc.in, err)
+		}
+		if got != tc.want {
+			t.Errorf("ParseFormat(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestRenderTable(t *testing.T) {
+	var buf bytes.Buffer
+	headers := []string{"ID", "NAME"}
+	rows := [][]string{{"1", "alice"}, {"2", "bob"}}
+
+	if err := Render(&buf, headers, rows, Options{Format: Table}); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "ID") || !strings.Contains(out, "NAME") {
+		t.Errorf("table output missing headers: %q", out)
+	}
+	if !strings.Contains(out, "alice") || !strings.Contains(out, "bob") {
+		t.Errorf("table output missing rows: %q", out)
+	}
+}
+
+func TestRenderTableQuietOmitsHeader(t *testing.T) {
+	var buf bytes.Buffer
+	headers := []string{"ID", "NAME"}
+	rows := [][]string{{"1", "alice"}}
+
+	if err := Render(&buf, headers, rows, Options{Format: Table, Quiet: true}); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	if out := buf.String(); strings.Contains(out, "ID") {
+		t.Errorf("quiet table output should omit headers, got %q", out)
+	}
+}
+
+func TestRenderCSV(t *testing.T) {
+	var buf bytes.Buffer
+	headers := []string{"ID", "NAME"}
+	rows := [][]string{{"1", "alice"}}
+
+	if err := Render(&buf, headers, rows, Options{Format: CSV}); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	want := "ID,NAME\n1,alice\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render(CSV) = %q, want %q", got, want)
+	}
+}
+
+func TestRenderCSVNoHeader(t *testing.T) {
+	var buf bytes.Buffer
+	headers := []string{"ID", "NAME"}
+	rows := [][]string{{"1", "alice"}}
+
+	if err := Render(&buf, headers, rows, Options{Format: CSV, NoHeader: true}); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	want := "1,alice\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Render(CSV, NoHeader) = %q, want %q", got, want)
+	}
+}
+
+func TestRenderJSON(t *testing.T) {
+	var buf bytes.Buffer
+	headers := []string{"ID", "NAME"}
+	rows := [][]string{{"1", "alice"}}
+
+	if err := Render(&buf, headers, rows, Options{Format: JSON}); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, `"ID": "1"`) || !strings.Contains(out, `"NAME": "alice"`) {
+		t.Errorf("json output missing expected fields: %q", out)
+	}
+}
+
+func TestRenderYAML(t *testing.T) {
+	var buf bytes.Buffer
+	headers := []string{"ID", "NAME"}
+	rows := [][]string{{"1", "alice"}}
+
+	if err := Render(&buf, headers, rows, Options{Format: YAML}); err != nil {
+		t.Fatalf("Render returned error: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "ID: \"1\"") || !strings.Contains(out, "NAME: alice") {
+		t.Errorf("yaml output missing expected fields: %q", out)
+	}
+}